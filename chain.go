@@ -0,0 +1,105 @@
+package configure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// chainChecker is a Checker that queries its sources in order and returns
+// the first hit. It is returned, as a Checker, by Chain.
+type chainChecker struct {
+	checkers []Checker
+}
+
+// Chain combines checkers into a single Checker that layers them: String,
+// Int, and Bool query each checker in order and return the first value
+// found, so callers can put overrides (e.g. OSEnv) ahead of defaults
+// (e.g. a YAML file) and look them up through one Checker.
+func Chain(checkers ...Checker) Checker {
+	return &chainChecker{checkers: checkers}
+}
+
+// Setup runs every checker's Setup concurrently and aggregates any
+// errors into one, so a single failing source doesn't block the others
+// from loading or hide behind whichever error happened to be seen first.
+func (c *chainChecker) Setup() error {
+	errs := make([]error, len(c.checkers))
+
+	var wg sync.WaitGroup
+	for i, checker := range c.checkers {
+		wg.Add(1)
+		go func(i int, checker Checker) {
+			defer wg.Done()
+			errs[i] = checker.Setup()
+		}(i, checker)
+	}
+	wg.Wait()
+
+	var messages []string
+	for i, err := range errs {
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("checker %d: %v", i, err))
+		}
+	}
+
+	if len(messages) > 0 {
+		return fmt.Errorf("configure: Chain.Setup: %s", strings.Join(messages, "; "))
+	}
+
+	return nil
+}
+
+// String returns the value of the first checker, in order, that has name
+// set.
+func (c *chainChecker) String(name string) (string, error) {
+	var err error
+	for _, checker := range c.checkers {
+		var v string
+		if v, err = checker.String(name); err == nil {
+			return v, nil
+		}
+	}
+
+	return "", notFound(name, err)
+}
+
+// Int returns the value of the first checker, in order, that has name
+// set.
+func (c *chainChecker) Int(name string) (int, error) {
+	var err error
+	for _, checker := range c.checkers {
+		var v int
+		if v, err = checker.Int(name); err == nil {
+			return v, nil
+		}
+	}
+
+	return 0, notFound(name, err)
+}
+
+// Bool returns the value of the first checker, in order, that has name
+// set.
+func (c *chainChecker) Bool(name string) (bool, error) {
+	var err error
+	for _, checker := range c.checkers {
+		var v bool
+		if v, err = checker.Bool(name); err == nil {
+			return v, nil
+		}
+	}
+
+	return false, notFound(name, err)
+}
+
+// notFound reports that name was missing from every checker in the
+// chain, wrapping the last checker's error, or a generic message if the
+// chain has no checkers at all.
+func notFound(name string, last error) error {
+	if last == nil {
+		return errors.New("configure: Chain has no checkers")
+	}
+
+	return fmt.Errorf("configure: %s: not found in any checker: %w", name, last)
+}
@@ -0,0 +1,55 @@
+package configure
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestChainPrefersEarlierChecker(t *testing.T) {
+	lower := dotEnvFromString("NAME=from-dotenv\nPORT=8080\nCONFIGURE_CHAIN_TEST_NAME=from-dotenv\n")
+	os.Setenv("CONFIGURE_CHAIN_TEST_NAME", "from-env")
+	defer os.Unsetenv("CONFIGURE_CHAIN_TEST_NAME")
+
+	osEnv := NewOSEnv()
+	c := Chain(osEnv, lower)
+
+	if err := c.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, err := c.String("CONFIGURE_CHAIN_TEST_NAME"); err != nil || v != "from-env" {
+		t.Errorf("String(name) = %q, %v, want %q, nil", v, err, "from-env")
+	}
+
+	if v, err := c.String("NAME"); err != nil || v != "from-dotenv" {
+		t.Errorf("String(NAME) = %q, %v, want %q, nil", v, err, "from-dotenv")
+	}
+
+	if v, err := c.Int("PORT"); err != nil || v != 8080 {
+		t.Errorf("Int(PORT) = %d, %v, want 8080, nil", v, err)
+	}
+
+	if _, err := c.String("DOES_NOT_EXIST"); err == nil {
+		t.Error("String(DOES_NOT_EXIST) error = nil, want error")
+	}
+}
+
+func TestChainSetupAggregatesErrors(t *testing.T) {
+	boom := errors.New("boom")
+	bad := NewDotEnv(func() (io.Reader, error) {
+		return nil, boom
+	})
+
+	c := Chain(bad, NewOSEnv())
+
+	err := c.Setup()
+	if err == nil {
+		t.Fatal("Setup() error = nil, want error")
+	}
+	if !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Setup() error = %v, want it to mention %q", err, "boom")
+	}
+}
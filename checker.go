@@ -0,0 +1,13 @@
+package configure
+
+// Checker is implemented by every configuration source in this package:
+// DotEnv, YAML, JSON, OSEnv, and the composite returned by Chain. Setup
+// loads (or reloads) the source, and String, Int, and Bool look up a
+// single named value, returning an error if it is unset or cannot be
+// converted to the requested type.
+type Checker interface {
+	Setup() error
+	String(name string) (string, error)
+	Int(name string) (int, error)
+	Bool(name string) (bool, error)
+}
@@ -1,132 +1,339 @@
 package configure
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 )
 
+// DotEnvOption configures optional behavior of a DotEnv instance.
+type DotEnvOption func(*DotEnv)
+
+// WithInterpolation enables or disables ${VAR} style interpolation of
+// values as they are parsed. Interpolation is disabled by default, to
+// keep existing callers' values byte-for-byte unchanged; pass
+// WithInterpolation(true) to opt in for callers that want ${VAR}
+// expansion.
+func WithInterpolation(enabled bool) DotEnvOption {
+	return func(h *DotEnv) {
+		h.interpolate = enabled
+	}
+}
+
 // NewDotEnv returns an instance of the DotEnv checker. It takes a function
 // which returns an io.Reader which will be called when the first value
 // is recalled. The contents of the io.Reader MUST follow the DotEnv format.
-func NewDotEnv(gen func() (io.Reader, error)) *DotEnv {
-	return &DotEnv{
+func NewDotEnv(gen func() (io.Reader, error), opts ...DotEnvOption) *DotEnv {
+	h := &DotEnv{
 		gen: gen,
 	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
 }
 
 // NewDotEnvFromFile returns an instance of the DotEnv checker. It reads its
 // data from a file which its location has been specified through the path
 // parameter
-func NewDotEnvFromFile(path string) *DotEnv {
-	return NewDotEnv(func() (io.Reader, error) {
+func NewDotEnvFromFile(path string, opts ...DotEnvOption) *DotEnv {
+	h := NewDotEnv(func() (io.Reader, error) {
 		return os.Open(path)
-	})
+	}, opts...)
+	h.path = path
+
+	return h
 }
 
 // DotEnv represents the DotEnv Checker. It reads an io.Reader and then pulls a value out of a map[string]interface{}.
 type DotEnv struct {
-	values map[string]interface{}
-	gen    func() (io.Reader, error)
+	mu          sync.RWMutex
+	values      map[string]interface{}
+	gen         func() (io.Reader, error)
+	interpolate bool
+
+	// path is only set by NewDotEnvFromFile; it is what makes a DotEnv
+	// eligible for Watch.
+	path string
+	// watching and cancel are set while a Watch goroutine is running; see
+	// watch.go.
+	watching bool
+	cancel   func()
+	wg       sync.WaitGroup
 }
 
 // Setup initializes the DotEnv Checker
 func (h *DotEnv) Setup() error {
-	r, err := h.gen()
+	values, err := h.parse()
 	if err != nil {
 		return err
 	}
 
-	h.values = make(map[string]interface{})
-	var lines []string
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+	h.mu.Lock()
+	h.values = values
+	h.mu.Unlock()
+
+	return nil
+}
+
+// parse reads and fully parses the DotEnv source into a fresh map,
+// without touching h.values. Setup uses it for the initial load, and
+// Watch uses it to build each reloaded snapshot before swapping it in.
+func (h *DotEnv) parse() (map[string]interface{}, error) {
+	r, err := h.gen()
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := r.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, fullLine := range lines {
-		if !isIgnoredLine(fullLine) {
-			key, value, err := parseLine(fullLine)
+	content := string(raw)
+	content = strings.TrimPrefix(content, "\uFEFF")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+
+	values := make(map[string]interface{})
+
+	for _, statement := range splitStatements(content) {
+		if isIgnoredLine(firstLine(statement)) {
+			continue
+		}
 
-			if err == nil {
-				h.values[key] = value
+		key, value, quote, err := parseStatement(statement, h.interpolate)
+		if err != nil {
+			continue
+		}
+
+		if h.interpolate && quote != quoteSingle {
+			value, err = h.resolve(values, value, map[string]bool{key: true})
+			if err != nil {
+				return nil, fmt.Errorf("configure: %s: %w", key, err)
 			}
 		}
+
+		values[key] = value
 	}
 
-	return nil
+	return values, nil
 }
 
-func parseLine(line string) (key string, value string, err error) {
-	if len(line) == 0 {
-		err = errors.New("zero length string")
-		return
+// quoteStyle records which quoting, if any, surrounded a parsed value so
+// that interpolation can honor POSIX single-quote semantics (no expansion).
+type quoteStyle int
+
+const (
+	quoteNone quoteStyle = iota
+	quoteSingle
+	quoteDouble
+)
+
+// splitStatements groups the physical lines of content into logical
+// KEY=VALUE statements. A statement normally ends at the first newline,
+// but when a value opens a single or double quote that isn't closed on
+// the same line, subsequent lines are folded in (the literal newline
+// becomes part of the value) until the matching close quote is found.
+func splitStatements(content string) []string {
+	lines := strings.Split(content, "\n")
+
+	var statements []string
+	var pending []string
+	valueStart := -1
+
+	flush := func() {
+		if len(pending) > 0 {
+			statements = append(statements, strings.Join(pending, "\n"))
+		}
+		pending = nil
+		valueStart = -1
 	}
 
-	// ditch the comments (but keep quoted hashes)
-	if strings.Contains(line, "#") {
-		segmentsBetweenHashes := strings.Split(line, "#")
-		quotesAreOpen := false
-		var segmentsToKeep []string
-		for _, segment := range segmentsBetweenHashes {
-			if strings.Count(segment, "\"") == 1 || strings.Count(segment, "'") == 1 {
-				if quotesAreOpen {
-					quotesAreOpen = false
-					segmentsToKeep = append(segmentsToKeep, segment)
-				} else {
-					quotesAreOpen = true
-				}
+	for _, line := range lines {
+		pending = append(pending, line)
+
+		if valueStart == -1 {
+			if isIgnoredLine(line) {
+				flush()
+				continue
+			}
+			idx := indexOfSeparator(line)
+			if idx == -1 {
+				flush()
+				continue
 			}
+			valueStart = idx
+		}
+
+		joined := strings.Join(pending, "\n")
+		rawValue := strings.TrimLeft(joined[valueStart+1:], " \t")
 
-			if len(segmentsToKeep) == 0 || quotesAreOpen {
-				segmentsToKeep = append(segmentsToKeep, segment)
+		if len(rawValue) > 0 && (rawValue[0] == '"' || rawValue[0] == '\'') {
+			var closed bool
+			if rawValue[0] == '"' {
+				closed = findClosingDoubleQuote(rawValue[1:]) != -1
+			} else {
+				closed = findClosingSingleQuote(rawValue[1:]) != -1
+			}
+			if !closed {
+				continue
 			}
 		}
 
-		line = strings.Join(segmentsToKeep, "#")
+		flush()
 	}
+	flush()
 
-	// now split key from value
-	splitString := strings.SplitN(line, "=", 2)
+	return statements
+}
 
-	if len(splitString) != 2 {
-		// try yaml mode!
-		splitString = strings.SplitN(line, ":", 2)
+// parseStatement splits a (possibly multi-line) statement produced by
+// splitStatements into its key and value, honoring quoting and, for
+// double-quoted values, backslash escape sequences. interpolate is passed
+// through to unescapeDouble.
+func parseStatement(statement string, interpolate bool) (key string, value string, quote quoteStyle, err error) {
+	if len(statement) == 0 {
+		err = errors.New("zero length string")
+		return
 	}
 
-	if len(splitString) != 2 {
+	idx := indexOfSeparator(statement)
+	if idx == -1 {
 		err = errors.New("Can't separate key from value")
 		return
 	}
 
-	// Parse the key
-	key = splitString[0]
-	if strings.HasPrefix(key, "export") {
-		key = strings.TrimPrefix(key, "export")
+	key = strings.TrimPrefix(statement[:idx], "export")
+	key = strings.Trim(key, " \t")
+
+	raw := strings.TrimLeft(statement[idx+1:], " \t")
+
+	switch {
+	case strings.HasPrefix(raw, "\""):
+		closeIdx := findClosingDoubleQuote(raw[1:])
+		if closeIdx == -1 {
+			err = errors.New("unterminated double-quoted value")
+			return
+		}
+		quote = quoteDouble
+		value = unescapeDouble(raw[1:1+closeIdx], interpolate)
+	case strings.HasPrefix(raw, "'"):
+		closeIdx := findClosingSingleQuote(raw[1:])
+		if closeIdx == -1 {
+			err = errors.New("unterminated single-quoted value")
+			return
+		}
+		quote = quoteSingle
+		value = raw[1 : 1+closeIdx]
+	default:
+		value = strings.Trim(stripInlineComment(raw), " \t")
 	}
-	key = strings.Trim(key, " ")
 
-	// Parse the value
-	value = splitString[1]
-	// trim
-	value = strings.Trim(value, " ")
+	return
+}
 
-	// check if we've got quoted values
-	if strings.Count(value, "\"") == 2 || strings.Count(value, "'") == 2 {
-		// pull the quotes off the edges
-		value = strings.Trim(value, "\"'")
+// indexOfSeparator locates the key/value boundary of a line, preferring
+// "=" (the standard DotEnv form) and falling back to ":" (yaml mode).
+func indexOfSeparator(line string) int {
+	if idx := strings.Index(line, "="); idx != -1 {
+		return idx
+	}
+	return strings.Index(line, ":")
+}
 
-		// expand quotes
-		value = strings.Replace(value, "\\\"", "\"", -1)
-		// expand newlines
-		value = strings.Replace(value, "\\n", "\n", -1)
+// findClosingDoubleQuote returns the index, within s, of the first
+// unescaped '"', or -1 if none is found.
+func findClosingDoubleQuote(s string) int {
+	escaped := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case s[i] == '\\':
+			escaped = true
+		case s[i] == '"':
+			return i
+		}
 	}
+	return -1
+}
 
-	return
+// findClosingSingleQuote returns the index, within s, of the first single
+// quote character, or -1 if none is found. Single-quoted values have no
+// escape sequences.
+func findClosingSingleQuote(s string) int {
+	return strings.IndexByte(s, '\'')
+}
+
+// unescapeDouble expands the backslash escapes recognized inside a
+// double-quoted value: \n, \r, \t, \\, \", and \$. \$ yields a literal
+// "$". When interpolate is true it is written as the "$$" escape that the
+// interpolation pass in resolve collapses to a literal "$", so escaping
+// survives the later interpolation step intact.
+func unescapeDouble(s string, interpolate bool) string {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'n':
+				out.WriteByte('\n')
+				i++
+				continue
+			case 'r':
+				out.WriteByte('\r')
+				i++
+				continue
+			case 't':
+				out.WriteByte('\t')
+				i++
+				continue
+			case '\\':
+				out.WriteByte('\\')
+				i++
+				continue
+			case '"':
+				out.WriteByte('"')
+				i++
+				continue
+			case '$':
+				if interpolate {
+					out.WriteString("$$")
+				} else {
+					out.WriteByte('$')
+				}
+				i++
+				continue
+			}
+		}
+		out.WriteByte(s[i])
+	}
+	return out.String()
+}
+
+// stripInlineComment trims a trailing "# ..." comment from an unquoted
+// value.
+func stripInlineComment(value string) string {
+	if idx := strings.Index(value, "#"); idx != -1 {
+		return value[:idx]
+	}
+	return value
+}
+
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx != -1 {
+		return s[:idx]
+	}
+	return s
 }
 
 func isIgnoredLine(line string) bool {
@@ -134,7 +341,162 @@ func isIgnoredLine(line string) bool {
 	return len(trimmedLine) == 0 || strings.HasPrefix(trimmedLine, "#")
 }
 
+// reference is a single $VAR or ${VAR...} occurrence found while
+// interpolating a value.
+type reference struct {
+	name string
+	op   string // "", ":-", "-", or ":?"
+	arg  string
+}
+
+// resolve expands $VAR, ${VAR}, ${VAR:-default}, ${VAR-default}, and
+// ${VAR:?err} references in value, honoring $$ as an escaped literal `$`.
+// resolving tracks the keys currently being expanded so that a variable
+// which transitively references itself is reported as an error instead of
+// recursing forever.
+func (h *DotEnv) resolve(values map[string]interface{}, value string, resolving map[string]bool) (string, error) {
+	var out strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c != '$' {
+			out.WriteByte(c)
+			continue
+		}
+
+		if i+1 < len(value) && value[i+1] == '$' {
+			out.WriteByte('$')
+			i++
+			continue
+		}
+
+		ref, width, err := parseReference(value[i:])
+		if err != nil {
+			return "", err
+		}
+		if width == 0 {
+			out.WriteByte(c)
+			continue
+		}
+
+		resolved, err := h.resolveRef(values, ref, resolving)
+		if err != nil {
+			return "", err
+		}
+
+		out.WriteString(resolved)
+		i += width - 1
+	}
+
+	return out.String(), nil
+}
+
+func (h *DotEnv) resolveRef(values map[string]interface{}, ref reference, resolving map[string]bool) (string, error) {
+	if resolving[ref.name] {
+		return "", fmt.Errorf("%s: variable references itself", ref.name)
+	}
+
+	current, ok := values[ref.name].(string)
+	if !ok {
+		current, ok = os.LookupEnv(ref.name)
+	}
+
+	expandArg := func() (string, error) {
+		resolving[ref.name] = true
+		defer delete(resolving, ref.name)
+		return h.resolve(values, ref.arg, resolving)
+	}
+
+	switch ref.op {
+	case ":-":
+		if !ok || current == "" {
+			return expandArg()
+		}
+	case "-":
+		if !ok {
+			return expandArg()
+		}
+	case ":?":
+		if !ok || current == "" {
+			msg, err := expandArg()
+			if err != nil {
+				return "", err
+			}
+			if msg == "" {
+				msg = fmt.Sprintf("%s: required variable is empty or unset", ref.name)
+			}
+			return "", errors.New(msg)
+		}
+	}
+
+	return current, nil
+}
+
+// parseReference parses a single reference starting at s[0] == '$'. It
+// returns the zero reference and a width of 0 if s does not begin a valid
+// reference (e.g. a bare "$" at end of string).
+func parseReference(s string) (reference, int, error) {
+	if len(s) < 2 {
+		return reference{}, 0, nil
+	}
+
+	if s[1] != '{' {
+		if !isNameStartByte(s[1]) {
+			return reference{}, 0, nil
+		}
+		j := 1
+		for j < len(s) && isNameByte(s[j]) {
+			j++
+		}
+		return reference{name: s[1:j]}, j, nil
+	}
+
+	depth := 1
+	j := 2
+	for ; j < len(s); j++ {
+		switch s[j] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if depth != 0 {
+		return reference{}, 0, errors.New("unterminated ${...} reference")
+	}
+
+	ref := splitReferenceBody(s[2:j])
+	return ref, j + 1, nil
+}
+
+func splitReferenceBody(body string) reference {
+	for _, op := range []string{":-", ":?", "-"} {
+		if idx := strings.Index(body, op); idx >= 0 {
+			return reference{name: body[:idx], op: op, arg: body[idx+len(op):]}
+		}
+	}
+	return reference{name: body}
+}
+
+func isNameByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// isNameStartByte reports whether b may begin a bare $VAR reference name.
+// Unlike isNameByte, digits are excluded: POSIX/shell variable names
+// can't start with one, so "$5.00" is left as a literal dollar sign
+// rather than being parsed as a reference to a variable named "5".
+func isNameStartByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
 func (h *DotEnv) value(name string) (interface{}, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
 	val, ok := h.values[name]
 	if !ok {
 		return nil, errors.New("variable does not exist")
@@ -143,6 +505,34 @@ func (h *DotEnv) value(name string) (interface{}, error) {
 	return val, nil
 }
 
+// ensureSetup runs Setup if h holds no parsed values yet, so Load,
+// Overload, and Environ work without requiring an explicit Setup call.
+func (h *DotEnv) ensureSetup() error {
+	h.mu.RLock()
+	initialized := h.values != nil
+	h.mu.RUnlock()
+
+	if initialized {
+		return nil
+	}
+
+	return h.Setup()
+}
+
+// snapshot returns a copy of the current values, safe to range over
+// without holding h.mu.
+func (h *DotEnv) snapshot() map[string]interface{} {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	values := make(map[string]interface{}, len(h.values))
+	for k, v := range h.values {
+		values[k] = v
+	}
+
+	return values
+}
+
 // Int returns an int if it exists within the DotEnv io.Reader
 func (h *DotEnv) Int(name string) (int, error) {
 	v, err := h.value(name)
@@ -183,3 +573,76 @@ func (h *DotEnv) String(name string) (string, error) {
 
 	return v.(string), nil
 }
+
+// Load parses the DotEnv source, if it hasn't been already, and publishes
+// its values into the process environment via os.Setenv. A key that is
+// already set in the environment is left untouched, mirroring godotenv's
+// Load semantics.
+func (h *DotEnv) Load() error {
+	if err := h.ensureSetup(); err != nil {
+		return err
+	}
+
+	for key, value := range h.snapshot() {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		if err := os.Setenv(key, fmt.Sprintf("%v", value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Overload behaves like Load but unconditionally calls os.Setenv, so an
+// existing environment variable is replaced by the value from the source.
+func (h *DotEnv) Overload() error {
+	if err := h.ensureSetup(); err != nil {
+		return err
+	}
+
+	for key, value := range h.snapshot() {
+		if err := os.Setenv(key, fmt.Sprintf("%v", value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Environ returns the parsed values formatted as "KEY=VALUE" strings, the
+// shape exec.Cmd.Env and os.Environ expect.
+func (h *DotEnv) Environ() []string {
+	if err := h.ensureSetup(); err != nil {
+		return nil
+	}
+
+	values := h.snapshot()
+	env := make([]string, 0, len(values))
+	for key, value := range values {
+		env = append(env, fmt.Sprintf("%s=%v", key, value))
+	}
+
+	return env
+}
+
+// Load reads each of the given DotEnv files, in order, and publishes their
+// values into the process environment. It follows a first-file-wins
+// policy: a key set by an earlier file, or already present in the
+// environment, is left alone when a later file also defines it. This
+// makes the package a drop-in replacement for the common
+// godotenv.Load(paths...) workflow.
+func Load(paths ...string) error {
+	if len(paths) == 0 {
+		paths = []string{".env"}
+	}
+
+	for _, path := range paths {
+		if err := NewDotEnvFromFile(path).Load(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,14 @@
 package configure
 
-import "testing"
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestDotEnv(t *testing.T) {
 	dotEnv := NewDotEnvFromFile("dotenv")
@@ -27,3 +35,413 @@ func TestDotEnv(t *testing.T) {
 		t.Error("hello2")
 	}
 }
+
+func dotEnvFromString(content string, opts ...DotEnvOption) *DotEnv {
+	return NewDotEnv(func() (io.Reader, error) {
+		return strings.NewReader(content), nil
+	}, opts...)
+}
+
+func TestDotEnvInterpolation(t *testing.T) {
+	dotEnv := dotEnvFromString("HOST=localhost\nURL=http://${HOST}:${PORT:-8080}/${ROUTE}\nROUTE=api\n", WithInterpolation(true))
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("URL"); v != "http://localhost:8080/" {
+		t.Errorf("URL = %q, want %q", v, "http://localhost:8080/")
+	}
+}
+
+func TestDotEnvInterpolationDefaultOnlyWhenUnset(t *testing.T) {
+	dotEnv := dotEnvFromString("EMPTY=\nA=${EMPTY-fallback}\nB=${EMPTY:-fallback}\n", WithInterpolation(true))
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("A"); v != "" {
+		t.Errorf("A = %q, want empty (VAR-default only applies when unset)", v)
+	}
+
+	if v, _ := dotEnv.String("B"); v != "fallback" {
+		t.Errorf("B = %q, want %q (VAR:-default applies when empty)", v, "fallback")
+	}
+}
+
+func TestDotEnvInterpolationRequired(t *testing.T) {
+	dotEnv := dotEnvFromString("REQUIRED=${MISSING:?MISSING must be set}\n", WithInterpolation(true))
+
+	if err := dotEnv.Setup(); err == nil {
+		t.Error("Setup() error = nil, want error for unset required variable")
+	}
+}
+
+func TestDotEnvInterpolationEnvFallback(t *testing.T) {
+	os.Setenv("CONFIGURE_TEST_VAR", "from-env")
+	defer os.Unsetenv("CONFIGURE_TEST_VAR")
+
+	dotEnv := dotEnvFromString("V=${CONFIGURE_TEST_VAR}\n", WithInterpolation(true))
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("V"); v != "from-env" {
+		t.Errorf("V = %q, want %q", v, "from-env")
+	}
+}
+
+func TestDotEnvInterpolationEscaping(t *testing.T) {
+	dotEnv := dotEnvFromString("PRICE=$$5.00\n", WithInterpolation(true))
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("PRICE"); v != "$5.00" {
+		t.Errorf("PRICE = %q, want %q", v, "$5.00")
+	}
+}
+
+func TestDotEnvInterpolationDollarDigitIsLiteral(t *testing.T) {
+	dotEnv := dotEnvFromString("PRICE=$5.00\n", WithInterpolation(true))
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("PRICE"); v != "$5.00" {
+		t.Errorf("PRICE = %q, want %q (variable names can't start with a digit)", v, "$5.00")
+	}
+}
+
+func TestDotEnvInterpolationSingleQuoteIsLiteral(t *testing.T) {
+	dotEnv := dotEnvFromString("RAW='${NOT_EXPANDED}'\n", WithInterpolation(true))
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("RAW"); v != "${NOT_EXPANDED}" {
+		t.Errorf("RAW = %q, want literal %q", v, "${NOT_EXPANDED}")
+	}
+}
+
+func TestDotEnvInterpolationSelfReferenceLoop(t *testing.T) {
+	dotEnv := dotEnvFromString("A=${A:-${A}}\n", WithInterpolation(true))
+
+	if err := dotEnv.Setup(); err == nil {
+		t.Error("Setup() error = nil, want error for self-referential variable")
+	}
+}
+
+func TestDotEnvMultilineFixtures(t *testing.T) {
+	dotEnv := NewDotEnvFromFile("fixtures/multiline.env")
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	cases := map[string]string{
+		"OPTION_J": "line1\nline2",
+		"OPTION_K": "line1\nline2",
+		"OPTION_L": "line1\nline2",
+		"OPTION_M": "quote \" inside",
+	}
+
+	for key, want := range cases {
+		v, err := dotEnv.String(key)
+		if err != nil {
+			t.Errorf("%s: %v", key, err)
+			continue
+		}
+		if v != want {
+			t.Errorf("%s = %q, want %q", key, v, want)
+		}
+	}
+}
+
+func TestDotEnvCRLFLineEndings(t *testing.T) {
+	dotEnv := dotEnvFromString("A=1\r\nB=2\r\n")
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("A"); v != "1" {
+		t.Errorf("A = %q, want %q", v, "1")
+	}
+	if v, _ := dotEnv.String("B"); v != "2" {
+		t.Errorf("B = %q, want %q", v, "2")
+	}
+}
+
+func TestDotEnvStripsLeadingBOM(t *testing.T) {
+	dotEnv := dotEnvFromString("\uFEFFA=1\n")
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("A"); v != "1" {
+		t.Errorf("A = %q, want %q", v, "1")
+	}
+}
+
+func TestDotEnvLoadSkipsExistingEnv(t *testing.T) {
+	os.Setenv("CONFIGURE_TEST_LOAD", "from-env")
+	defer os.Unsetenv("CONFIGURE_TEST_LOAD")
+	defer os.Unsetenv("CONFIGURE_TEST_LOAD_NEW")
+
+	dotEnv := dotEnvFromString("CONFIGURE_TEST_LOAD=from-file\nCONFIGURE_TEST_LOAD_NEW=from-file\n")
+
+	if err := dotEnv.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if v := os.Getenv("CONFIGURE_TEST_LOAD"); v != "from-env" {
+		t.Errorf("CONFIGURE_TEST_LOAD = %q, want existing env value preserved", v)
+	}
+
+	if v := os.Getenv("CONFIGURE_TEST_LOAD_NEW"); v != "from-file" {
+		t.Errorf("CONFIGURE_TEST_LOAD_NEW = %q, want %q", v, "from-file")
+	}
+}
+
+func TestDotEnvOverloadReplacesExistingEnv(t *testing.T) {
+	os.Setenv("CONFIGURE_TEST_OVERLOAD", "from-env")
+	defer os.Unsetenv("CONFIGURE_TEST_OVERLOAD")
+
+	dotEnv := dotEnvFromString("CONFIGURE_TEST_OVERLOAD=from-file\n")
+
+	if err := dotEnv.Overload(); err != nil {
+		t.Fatalf("Overload() error = %v", err)
+	}
+
+	if v := os.Getenv("CONFIGURE_TEST_OVERLOAD"); v != "from-file" {
+		t.Errorf("CONFIGURE_TEST_OVERLOAD = %q, want %q", v, "from-file")
+	}
+}
+
+func TestDotEnvEnviron(t *testing.T) {
+	dotEnv := dotEnvFromString("A=1\nB=2\n")
+
+	env := dotEnv.Environ()
+
+	want := map[string]bool{"A=1": true, "B=2": true}
+	if len(env) != len(want) {
+		t.Fatalf("Environ() = %v, want entries for %v", env, want)
+	}
+	for _, kv := range env {
+		if !want[kv] {
+			t.Errorf("Environ() contained unexpected entry %q", kv)
+		}
+	}
+}
+
+func TestLoadFirstFileWins(t *testing.T) {
+	dir := t.TempDir()
+
+	first := filepath.Join(dir, "first.env")
+	second := filepath.Join(dir, "second.env")
+
+	if err := os.WriteFile(first, []byte("CONFIGURE_TEST_PKG_LOAD=from-first\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte("CONFIGURE_TEST_PKG_LOAD=from-second\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv("CONFIGURE_TEST_PKG_LOAD")
+
+	if err := Load(first, second); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if v := os.Getenv("CONFIGURE_TEST_PKG_LOAD"); v != "from-first" {
+		t.Errorf("CONFIGURE_TEST_PKG_LOAD = %q, want %q", v, "from-first")
+	}
+}
+
+func TestDotEnvWatchRequiresFileBacked(t *testing.T) {
+	dotEnv := dotEnvFromString("A=1\n")
+
+	if err := dotEnv.Watch(context.Background(), nil); err == nil {
+		t.Error("Watch() error = nil, want error for non-file-backed DotEnv")
+	}
+}
+
+func TestDotEnvWatchReloadsOnChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "watched.env")
+
+	if err := os.WriteFile(path, []byte("A=1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan error, 4)
+	dotEnv, err := WatchFile(ctx, path, func(_ *DotEnv, err error) {
+		changes <- err
+	})
+	if err != nil {
+		t.Fatalf("WatchFile() error = %v", err)
+	}
+	defer dotEnv.Close()
+
+	if v, _ := dotEnv.String("A"); v != "1" {
+		t.Fatalf("A = %q, want %q", v, "1")
+	}
+
+	if err := os.WriteFile(path, []byte("A=2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-changes:
+		if err != nil {
+			t.Fatalf("onChange error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for Watch to reload")
+	}
+
+	if v, _ := dotEnv.String("A"); v != "2" {
+		t.Errorf("A = %q, want %q after reload", v, "2")
+	}
+}
+
+func TestDotEnvWatchReloadsOnRename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "watched.env")
+
+	if err := os.WriteFile(path, []byte("A=1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan error, 4)
+	dotEnv, err := WatchFile(ctx, path, func(_ *DotEnv, err error) {
+		changes <- err
+	})
+	if err != nil {
+		t.Fatalf("WatchFile() error = %v", err)
+	}
+	defer dotEnv.Close()
+
+	if v, _ := dotEnv.String("A"); v != "1" {
+		t.Fatalf("A = %q, want %q", v, "1")
+	}
+
+	// Mimic vim's atomic save: write the new content to a temp file in
+	// the same directory, then rename it over path. This fires a
+	// Remove/Rename event on the old inode rather than a Write; that
+	// event alone must trigger the debounced reload.
+	tmp := filepath.Join(dir, "watched.env.tmp")
+	if err := os.WriteFile(tmp, []byte("A=2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-changes:
+		if err != nil {
+			t.Fatalf("onChange error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for Watch to reload after rename")
+	}
+
+	if v, _ := dotEnv.String("A"); v != "2" {
+		t.Errorf("A = %q, want %q after reload", v, "2")
+	}
+}
+
+func TestDotEnvWatchCloseDrainsPendingReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "watched.env")
+
+	if err := os.WriteFile(path, []byte("A=1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan error, 4)
+	dotEnv, err := WatchFile(ctx, path, func(_ *DotEnv, err error) {
+		changes <- err
+	})
+	if err != nil {
+		t.Fatalf("WatchFile() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("A"); v != "1" {
+		t.Fatalf("A = %q, want %q", v, "1")
+	}
+
+	// Write a change and, without waiting for watchDebounce to elapse,
+	// close immediately. Close must not return until the watchLoop
+	// goroutine has fully exited, so the pending debounced reload is
+	// either run to completion or abandoned before Close returns --
+	// never left to fire afterward.
+	if err := os.WriteFile(path, []byte("A=2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		dotEnv.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close() did not return")
+	}
+
+	drain := func() int {
+		n := 0
+		for {
+			select {
+			case <-changes:
+				n++
+			default:
+				return n
+			}
+		}
+	}
+
+	// Whatever fired (or didn't) by the time Close() returned is fine --
+	// either the pending reload completed first, or it was abandoned.
+	// What matters is that nothing fires afterward.
+	drain()
+
+	// Give a stray, untracked reload (the bug this test guards against)
+	// time to fire if one is still pending somewhere.
+	time.Sleep(watchDebounce * 3)
+
+	if n := drain(); n > 0 {
+		t.Errorf("onChange fired after Close() returned; a reload leaked past Close")
+	}
+}
+
+func TestDotEnvInterpolationDisabled(t *testing.T) {
+	dotEnv := dotEnvFromString("V=${NOT_EXPANDED}\n", WithInterpolation(false))
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, _ := dotEnv.String("V"); v != "${NOT_EXPANDED}" {
+		t.Errorf("V = %q, want literal %q", v, "${NOT_EXPANDED}")
+	}
+}
@@ -0,0 +1,109 @@
+package configure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// JSON is a Checker backed by a JSON document containing a flat object of
+// string keys to scalar values. It is not suited for nested documents;
+// Setup only looks at the top-level keys.
+type JSON struct {
+	mu     sync.RWMutex
+	values map[string]interface{}
+	gen    func() (io.Reader, error)
+}
+
+// NewJSON returns a JSON Checker. It takes a function which returns an
+// io.Reader which will be called when Setup runs.
+func NewJSON(gen func() (io.Reader, error)) *JSON {
+	return &JSON{gen: gen}
+}
+
+// NewJSONFromFile returns a JSON Checker that reads its data from the
+// file at path.
+func NewJSONFromFile(path string) *JSON {
+	return NewJSON(func() (io.Reader, error) {
+		return os.Open(path)
+	})
+}
+
+// Setup initializes the JSON Checker.
+func (j *JSON) Setup() error {
+	r, err := j.gen()
+	if err != nil {
+		return err
+	}
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return fmt.Errorf("configure: %w", err)
+	}
+
+	j.mu.Lock()
+	j.values = values
+	j.mu.Unlock()
+
+	return nil
+}
+
+func (j *JSON) value(name string) (interface{}, error) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	val, ok := j.values[name]
+	if !ok {
+		return nil, errors.New("variable does not exist")
+	}
+
+	return val, nil
+}
+
+// String returns a string if it exists within the JSON document.
+func (j *JSON) String(name string) (string, error) {
+	v, err := j.value(name)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%v", v), nil
+}
+
+// Int returns an int if it exists within the JSON document.
+func (j *JSON) Int(name string) (int, error) {
+	v, err := j.value(name)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n := v.(type) {
+	case float64:
+		return int(n), nil
+	default:
+		return strconv.Atoi(fmt.Sprintf("%v", v))
+	}
+}
+
+// Bool returns a bool if it exists within the JSON document.
+func (j *JSON) Bool(name string) (bool, error) {
+	v, err := j.value(name)
+	if err != nil {
+		return false, err
+	}
+
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+
+	return strconv.ParseBool(fmt.Sprintf("%v", v))
+}
@@ -0,0 +1,37 @@
+package configure
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func jsonFromString(content string) *JSON {
+	return NewJSON(func() (io.Reader, error) {
+		return strings.NewReader(content), nil
+	})
+}
+
+func TestJSON(t *testing.T) {
+	j := jsonFromString(`{"name":"api","port":9090,"debug":true}`)
+
+	if err := j.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, err := j.String("name"); err != nil || v != "api" {
+		t.Errorf("String(name) = %q, %v, want %q, nil", v, err, "api")
+	}
+
+	if v, err := j.Int("port"); err != nil || v != 9090 {
+		t.Errorf("Int(port) = %d, %v, want 9090, nil", v, err)
+	}
+
+	if v, err := j.Bool("debug"); err != nil || v != true {
+		t.Errorf("Bool(debug) = %v, %v, want true, nil", v, err)
+	}
+
+	if _, err := j.String("does-not-exist"); err == nil {
+		t.Error("String(does-not-exist) error = nil, want error")
+	}
+}
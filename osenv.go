@@ -0,0 +1,55 @@
+package configure
+
+import (
+	"errors"
+	"os"
+	"strconv"
+)
+
+// OSEnv is a Checker backed directly by the process environment. Setup is
+// a no-op since String, Int, and Bool already read live from os.Environ
+// via os.LookupEnv.
+type OSEnv struct{}
+
+// NewOSEnv returns an OSEnv Checker.
+func NewOSEnv() *OSEnv {
+	return &OSEnv{}
+}
+
+// Setup does nothing; OSEnv has no state to load.
+func (OSEnv) Setup() error {
+	return nil
+}
+
+// String returns a string if the named variable is set in the process
+// environment.
+func (OSEnv) String(name string) (string, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", errors.New("variable does not exist")
+	}
+
+	return v, nil
+}
+
+// Int returns an int if the named variable is set in the process
+// environment.
+func (OSEnv) Int(name string) (int, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return 0, errors.New("variable does not exist")
+	}
+
+	return strconv.Atoi(v)
+}
+
+// Bool returns a bool if the named variable is set in the process
+// environment.
+func (OSEnv) Bool(name string) (bool, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return false, errors.New("variable does not exist")
+	}
+
+	return strconv.ParseBool(v)
+}
@@ -0,0 +1,28 @@
+package configure
+
+import (
+	"os"
+	"testing"
+)
+
+func TestOSEnv(t *testing.T) {
+	os.Setenv("CONFIGURE_OSENV_TEST", "42")
+	defer os.Unsetenv("CONFIGURE_OSENV_TEST")
+
+	e := NewOSEnv()
+	if err := e.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, err := e.String("CONFIGURE_OSENV_TEST"); err != nil || v != "42" {
+		t.Errorf("String() = %q, %v, want %q, nil", v, err, "42")
+	}
+
+	if v, err := e.Int("CONFIGURE_OSENV_TEST"); err != nil || v != 42 {
+		t.Errorf("Int() = %d, %v, want 42, nil", v, err)
+	}
+
+	if _, err := e.String("CONFIGURE_OSENV_DOES_NOT_EXIST"); err == nil {
+		t.Error("String() error = nil, want error")
+	}
+}
@@ -0,0 +1,188 @@
+package configure
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unmarshal populates v, which must be a non-nil pointer to a struct,
+// from values already parsed by Setup, using `env:"KEY"` struct tags.
+// The tag's key may be followed by comma-separated modifiers:
+//
+//	default=VALUE  use VALUE when KEY is unset
+//	required       report an error if KEY is unset and there is no default
+//	sep=SEP        separator used to split a []string field (default ",")
+//	prefix=PREFIX  for a nested struct field, recurse into it with PREFIX
+//	               prepended to each of its own fields' keys
+//
+// Supported field kinds are string, bool, every int/uint width,
+// float32/64, time.Duration (via time.ParseDuration), time.Time (RFC3339),
+// []string, and prefixed nested structs. Fields without an `env` tag are
+// left untouched. Every required-but-missing field is collected and
+// reported in a single aggregated error rather than failing on the
+// first one.
+func (h *DotEnv) Unmarshal(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("configure: Unmarshal requires a non-nil pointer to a struct")
+	}
+
+	var missing []string
+	if err := h.unmarshalStruct(rv.Elem(), "", &missing); err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("configure: required variable(s) not set: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// envTag is the parsed form of an `env:"..."` struct tag.
+type envTag struct {
+	key       string
+	def       string
+	hasDef    bool
+	required  bool
+	sep       string
+	prefix    string
+	hasPrefix bool
+}
+
+func parseEnvTag(tag string) envTag {
+	parts := strings.Split(tag, ",")
+	t := envTag{key: strings.TrimSpace(parts[0]), sep: ","}
+
+	for _, mod := range parts[1:] {
+		mod = strings.TrimSpace(mod)
+		switch {
+		case mod == "required":
+			t.required = true
+		case strings.HasPrefix(mod, "default="):
+			t.def = strings.TrimPrefix(mod, "default=")
+			t.hasDef = true
+		case strings.HasPrefix(mod, "sep="):
+			t.sep = strings.TrimPrefix(mod, "sep=")
+		case strings.HasPrefix(mod, "prefix="):
+			t.prefix = strings.TrimPrefix(mod, "prefix=")
+			t.hasPrefix = true
+		}
+	}
+
+	return t
+}
+
+func (h *DotEnv) unmarshalStruct(rv reflect.Value, prefix string, missing *[]string) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		tag, ok := field.Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+
+		et := parseEnvTag(tag)
+		fv := rv.Field(i)
+
+		if et.hasPrefix {
+			if fv.Kind() != reflect.Struct {
+				return fmt.Errorf("configure: field %s: prefix modifier requires a struct field", field.Name)
+			}
+			if err := h.unmarshalStruct(fv, prefix+et.prefix, missing); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key := prefix + et.key
+		raw, err := h.String(key)
+		if err != nil {
+			switch {
+			case et.hasDef:
+				raw = et.def
+			case et.required:
+				*missing = append(*missing, key)
+				continue
+			default:
+				continue
+			}
+		}
+
+		if err := setField(fv, raw, et.sep); err != nil {
+			return fmt.Errorf("configure: field %s (%s): %w", field.Name, key, err)
+		}
+	}
+
+	return nil
+}
+
+func setField(fv reflect.Value, raw string, sep string) error {
+	switch fv.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	case time.Time:
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice element type %s", fv.Type().Elem())
+		}
+		var parts []string
+		if raw != "" {
+			parts = strings.Split(raw, sep)
+		}
+		fv.Set(reflect.ValueOf(parts))
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+
+	return nil
+}
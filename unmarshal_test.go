@@ -0,0 +1,117 @@
+package configure
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDotEnvUnmarshal(t *testing.T) {
+	dotEnv := dotEnvFromString(strings.Join([]string{
+		"NAME=api",
+		"PORT=9090",
+		"TIMEOUT=1500ms",
+		"STARTED_AT=2024-01-02T15:04:05Z",
+		"TAGS=a,b,c",
+		"DB_HOST=localhost",
+		"DB_PORT=5432",
+	}, "\n") + "\n")
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	type db struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT"`
+	}
+
+	var cfg struct {
+		Name      string        `env:"NAME"`
+		Port      int           `env:"PORT_OVERRIDE,default=8080"`
+		Timeout   time.Duration `env:"TIMEOUT"`
+		StartedAt time.Time     `env:"STARTED_AT"`
+		Tags      []string      `env:"TAGS"`
+		DB        db            `env:",prefix=DB_"`
+	}
+
+	if err := dotEnv.Unmarshal(&cfg); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if cfg.Name != "api" {
+		t.Errorf("Name = %q, want %q", cfg.Name, "api")
+	}
+	if cfg.Port != 8080 {
+		t.Errorf("Port = %d, want %d (default)", cfg.Port, 8080)
+	}
+	if cfg.Timeout != 1500*time.Millisecond {
+		t.Errorf("Timeout = %v, want %v", cfg.Timeout, 1500*time.Millisecond)
+	}
+	if want := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC); !cfg.StartedAt.Equal(want) {
+		t.Errorf("StartedAt = %v, want %v", cfg.StartedAt, want)
+	}
+	if len(cfg.Tags) != 3 || cfg.Tags[0] != "a" || cfg.Tags[2] != "c" {
+		t.Errorf("Tags = %v, want [a b c]", cfg.Tags)
+	}
+	if cfg.DB.Host != "localhost" || cfg.DB.Port != 5432 {
+		t.Errorf("DB = %+v, want {Host:localhost Port:5432}", cfg.DB)
+	}
+}
+
+func TestDotEnvUnmarshalRequiredMissing(t *testing.T) {
+	dotEnv := dotEnvFromString("NAME=api\n")
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	var cfg struct {
+		Name  string `env:"NAME"`
+		Debug bool   `env:"DEBUG,required"`
+		Token string `env:"TOKEN,required"`
+	}
+
+	err := dotEnv.Unmarshal(&cfg)
+	if err == nil {
+		t.Fatal("Unmarshal() error = nil, want aggregated error listing DEBUG and TOKEN")
+	}
+
+	for _, key := range []string{"DEBUG", "TOKEN"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error %q does not mention missing key %q", err, key)
+		}
+	}
+}
+
+func TestDotEnvUnmarshalIntOverflow(t *testing.T) {
+	dotEnv := dotEnvFromString("V=9999\n")
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	var cfg struct {
+		V int8 `env:"V"`
+	}
+
+	if err := dotEnv.Unmarshal(&cfg); err == nil {
+		t.Errorf("Unmarshal() error = nil, want overflow error; V = %d", cfg.V)
+	}
+}
+
+func TestDotEnvUnmarshalRejectsNonPointer(t *testing.T) {
+	dotEnv := dotEnvFromString("NAME=api\n")
+
+	if err := dotEnv.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	var cfg struct {
+		Name string `env:"NAME"`
+	}
+
+	if err := dotEnv.Unmarshal(cfg); err == nil {
+		t.Error("Unmarshal() error = nil, want error for non-pointer argument")
+	}
+}
@@ -0,0 +1,172 @@
+package configure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long Watch waits after the last filesystem event
+// before re-parsing, so that editors which emit several events per save
+// (truncate, write, chmod, ...) only trigger a single reload.
+const watchDebounce = 100 * time.Millisecond
+
+// Watch observes the file backing h for changes, via fsnotify, and
+// re-parses it on every change. Each reload builds a brand new values
+// map and swaps it into h under h.mu, so concurrent String/Int/Bool
+// callers always see either the old or the new snapshot, never a torn
+// one. After every (re)load attempt onChange, if non-nil, is invoked
+// with h and the error encountered while reloading (nil on success).
+//
+// Watch only works on a file-backed DotEnv (one created through
+// NewDotEnvFromFile or WatchFile); it returns an error otherwise. Watch
+// returns once the watcher is established; the actual watching runs on a
+// background goroutine until ctx is canceled or Close is called.
+func (h *DotEnv) Watch(ctx context.Context, onChange func(*DotEnv, error)) error {
+	h.mu.Lock()
+	if h.path == "" {
+		h.mu.Unlock()
+		return errors.New("configure: Watch requires a file-backed DotEnv; use NewDotEnvFromFile or WatchFile")
+	}
+	if h.watching {
+		h.mu.Unlock()
+		return errors.New("configure: Watch is already running")
+	}
+	path := h.path
+	h.mu.Unlock()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+
+	h.mu.Lock()
+	h.watching = true
+	h.cancel = cancel
+	h.mu.Unlock()
+
+	h.wg.Add(1)
+	go h.watchLoop(watchCtx, watcher, path, onChange)
+
+	return nil
+}
+
+// WatchFile constructs a file-backed DotEnv, parses it once, and starts
+// Watch on it, returning the DotEnv with its initial values already
+// loaded so callers can read from it immediately.
+func WatchFile(ctx context.Context, path string, onChange func(*DotEnv, error), opts ...DotEnvOption) (*DotEnv, error) {
+	h := NewDotEnvFromFile(path, opts...)
+
+	if err := h.Setup(); err != nil {
+		return nil, err
+	}
+
+	if err := h.Watch(ctx, onChange); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// Close stops the background watcher started by Watch, if any, and waits
+// for its goroutine to exit before returning.
+func (h *DotEnv) Close() error {
+	h.mu.Lock()
+	cancel := h.cancel
+	h.cancel = nil
+	h.watching = false
+	h.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	h.wg.Wait()
+
+	return nil
+}
+
+func (h *DotEnv) watchLoop(ctx context.Context, watcher *fsnotify.Watcher, path string, onChange func(*DotEnv, error)) {
+	defer h.wg.Done()
+	defer watcher.Close()
+
+	reload := func() {
+		values, err := h.parse()
+		if err == nil {
+			h.mu.Lock()
+			h.values = values
+			h.mu.Unlock()
+		}
+		if onChange != nil {
+			onChange(h, err)
+		}
+	}
+
+	// debounce's channel is read inline in the select below, rather than
+	// via time.AfterFunc, so that reload always runs on this goroutine:
+	// it stays covered by h.wg (Close genuinely waits for it to finish)
+	// and never overlaps a later reload triggered by a subsequent event.
+	var debounce *time.Timer
+	var debounceC <-chan time.Time
+	defer func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			// Editors that save via rename/replace (vim, some IDEs) fire
+			// Remove or Rename rather than Write, and the watch follows
+			// the old inode. Re-add the watch on the (new) file at path
+			// so subsequent saves keep being observed.
+			if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				_ = watcher.Add(path)
+			}
+
+			if debounce == nil {
+				debounce = time.NewTimer(watchDebounce)
+			} else {
+				if !debounce.Stop() {
+					<-debounce.C
+				}
+				debounce.Reset(watchDebounce)
+			}
+			debounceC = debounce.C
+
+		case <-debounceC:
+			// The timer has now fired and its channel is drained; drop
+			// it so the next event allocates a fresh one instead of
+			// calling Stop/drain on an already-fired timer.
+			debounce = nil
+			debounceC = nil
+			reload()
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			if onChange != nil {
+				onChange(h, err)
+			}
+		}
+	}
+}
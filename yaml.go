@@ -0,0 +1,112 @@
+package configure
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML is a Checker backed by a YAML document containing a flat mapping
+// of string keys to scalar values. It is not suited for nested documents;
+// Setup only looks at the top-level keys.
+type YAML struct {
+	mu     sync.RWMutex
+	values map[string]interface{}
+	gen    func() (io.Reader, error)
+}
+
+// NewYAML returns a YAML Checker. It takes a function which returns an
+// io.Reader which will be called when Setup runs.
+func NewYAML(gen func() (io.Reader, error)) *YAML {
+	return &YAML{gen: gen}
+}
+
+// NewYAMLFromFile returns a YAML Checker that reads its data from the
+// file at path.
+func NewYAMLFromFile(path string) *YAML {
+	return NewYAML(func() (io.Reader, error) {
+		return os.Open(path)
+	})
+}
+
+// Setup initializes the YAML Checker.
+func (y *YAML) Setup() error {
+	r, err := y.gen()
+	if err != nil {
+		return err
+	}
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(raw, &values); err != nil {
+		return fmt.Errorf("configure: %w", err)
+	}
+
+	y.mu.Lock()
+	y.values = values
+	y.mu.Unlock()
+
+	return nil
+}
+
+func (y *YAML) value(name string) (interface{}, error) {
+	y.mu.RLock()
+	defer y.mu.RUnlock()
+
+	val, ok := y.values[name]
+	if !ok {
+		return nil, errors.New("variable does not exist")
+	}
+
+	return val, nil
+}
+
+// String returns a string if it exists within the YAML document.
+func (y *YAML) String(name string) (string, error) {
+	v, err := y.value(name)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%v", v), nil
+}
+
+// Int returns an int if it exists within the YAML document.
+func (y *YAML) Int(name string) (int, error) {
+	v, err := y.value(name)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case float64:
+		return int(n), nil
+	default:
+		return strconv.Atoi(fmt.Sprintf("%v", v))
+	}
+}
+
+// Bool returns a bool if it exists within the YAML document.
+func (y *YAML) Bool(name string) (bool, error) {
+	v, err := y.value(name)
+	if err != nil {
+		return false, err
+	}
+
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+
+	return strconv.ParseBool(fmt.Sprintf("%v", v))
+}
@@ -0,0 +1,37 @@
+package configure
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func yamlFromString(content string) *YAML {
+	return NewYAML(func() (io.Reader, error) {
+		return strings.NewReader(content), nil
+	})
+}
+
+func TestYAML(t *testing.T) {
+	y := yamlFromString("name: api\nport: 9090\ndebug: true\n")
+
+	if err := y.Setup(); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	if v, err := y.String("name"); err != nil || v != "api" {
+		t.Errorf("String(name) = %q, %v, want %q, nil", v, err, "api")
+	}
+
+	if v, err := y.Int("port"); err != nil || v != 9090 {
+		t.Errorf("Int(port) = %d, %v, want 9090, nil", v, err)
+	}
+
+	if v, err := y.Bool("debug"); err != nil || v != true {
+		t.Errorf("Bool(debug) = %v, %v, want true, nil", v, err)
+	}
+
+	if _, err := y.String("does-not-exist"); err == nil {
+		t.Error("String(does-not-exist) error = nil, want error")
+	}
+}